@@ -0,0 +1,171 @@
+package list_fonts
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	axis_fine_step_fraction   = 0.01
+	axis_coarse_step_fraction = 0.1
+)
+
+// adjust_axis moves the value of the axis at axis_idx by delta steps of
+// size step_fraction * (max-min), clamped to the axis's [min, max] range.
+func (f *face_chooser) adjust_axis(delta int, coarse bool) bool {
+	cur, ok := f.Current()
+	if !ok || f.axis_idx < 0 || f.axis_idx >= len(cur.Axes) {
+		return false
+	}
+	a := cur.Axes[f.axis_idx]
+	step_fraction := axis_fine_step_fraction
+	if coarse {
+		step_fraction = axis_coarse_step_fraction
+	}
+	step := (a.Max - a.Min) * step_fraction
+	if step == 0 {
+		return false
+	}
+	nv := f.axis_values[a.Tag] + step*float64(delta)
+	if nv < a.Min {
+		nv = a.Min
+	}
+	if nv > a.Max {
+		nv = a.Max
+	}
+	if nv == f.axis_values[a.Tag] {
+		return false
+	}
+	f.axis_values[a.Tag] = nv
+	return true
+}
+
+func (f *face_chooser) move_axis(delta int) bool {
+	cur, ok := f.Current()
+	if !ok || len(cur.Axes) == 0 {
+		return false
+	}
+	ni := (f.axis_idx + delta + len(cur.Axes)) % len(cur.Axes)
+	if ni == f.axis_idx {
+		return false
+	}
+	f.axis_idx = ni
+	return true
+}
+
+func (f *face_chooser) move_feature(delta int) bool {
+	cur, ok := f.Current()
+	if !ok || len(cur.Features) == 0 {
+		return false
+	}
+	ni := (f.feature_idx + delta + len(cur.Features)) % len(cur.Features)
+	if ni == f.feature_idx {
+		return false
+	}
+	f.feature_idx = ni
+	return true
+}
+
+func (f *face_chooser) toggle_current_feature() bool {
+	cur, ok := f.Current()
+	if !ok || f.feature_idx < 0 || f.feature_idx >= len(cur.Features) {
+		return false
+	}
+	tag := cur.Features[f.feature_idx]
+	f.enabled_feature[tag] = !f.enabled_feature[tag]
+	return true
+}
+
+// axis_slider renders a single axis as a text bar at least 10 cells wide,
+// e.g. "wght [======----] 650" for a 100..900 wght axis at width 10.
+func axis_slider(a FontAxis, value float64, width int) string {
+	if width < 10 {
+		width = 10
+	}
+	frac := 0.0
+	if a.Max > a.Min {
+		frac = (value - a.Min) / (a.Max - a.Min)
+	}
+	filled := int(frac * float64(width))
+	bar := strings.Repeat("=", filled) + strings.Repeat("-", width-filled)
+	return fmt.Sprintf("%-4s [%s] %g", a.Tag, bar, value)
+}
+
+// feature_spec builds the kitty font_features-compatible spec for the
+// currently highlighted face, e.g. "FamilyName -liga +ss01 wdth=87.5 wght=650".
+// Every feature tag is listed (+ enabled, - disabled), then every axis;
+// both groups are sorted by tag.
+func (f *face_chooser) feature_spec() string {
+	cur, ok := f.Current()
+	if !ok {
+		return ""
+	}
+	parts := []string{cur.Family}
+	tags := append([]string(nil), cur.Features...)
+	sort.Strings(tags)
+	for _, tag := range tags {
+		if f.enabled_feature[tag] {
+			parts = append(parts, "+"+tag)
+		} else {
+			parts = append(parts, "-"+tag)
+		}
+	}
+	axis_tags := make([]string, 0, len(cur.Axes))
+	for _, a := range cur.Axes {
+		axis_tags = append(axis_tags, a.Tag)
+	}
+	sort.Strings(axis_tags)
+	for _, tag := range axis_tags {
+		parts = append(parts, fmt.Sprintf("%s=%g", tag, f.axis_values[tag]))
+	}
+	return strings.Join(parts, " ")
+}
+
+func (h *handler) draw_controls(f ListedFont, x, y int) int {
+	if f.IsVariable && len(f.Axes) > 0 {
+		h.lp.MoveCursorTo(x, y)
+		h.lp.PrintStyled("bold", "Axes")
+		y++
+		for i, a := range f.Axes {
+			h.lp.MoveCursorTo(x, y+i)
+			line := axis_slider(a, h.faces.axis_values[a.Tag], 20)
+			if h.faces.focus == FOCUS_AXES && h.faces.axis_idx == i {
+				h.lp.PrintStyled("fg=green bold", line)
+			} else {
+				h.lp.QueueWriteString(line)
+			}
+		}
+		y += len(f.Axes) + 1
+	}
+	if len(f.Features) > 0 {
+		h.lp.MoveCursorTo(x, y)
+		h.lp.PrintStyled("bold", "Features")
+		y++
+		for i, tag := range f.Features {
+			h.lp.MoveCursorTo(x, y+i)
+			box := "[ ]"
+			if h.faces.enabled_feature[tag] {
+				box = "[x]"
+			}
+			line := box + " " + tag
+			if h.faces.focus == FOCUS_FEATURES && h.faces.feature_idx == i {
+				h.lp.PrintStyled("fg=green bold", line)
+			} else {
+				h.lp.QueueWriteString(line)
+			}
+		}
+		y += len(f.Features) + 1
+	}
+	return y
+}
+
+// accept_controls stashes the font_features spec for the current face on
+// the handler and quits the kitten. It must not write to stdout itself:
+// the loop still owns the terminal (alternate screen, raw mode) at this
+// point, so main prints h.accepted_spec only after lp.Run() has returned
+// and the terminal has been restored.
+func (h *handler) accept_controls() {
+	h.accepted_spec = h.faces.feature_spec()
+	h.lp.Quit(0)
+}
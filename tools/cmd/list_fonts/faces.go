@@ -0,0 +1,276 @@
+package list_fonts
+
+import (
+	"strings"
+
+	"kitty/tools/tui/loop"
+)
+
+// Choosing faces {{{
+
+// control_focus identifies which panel of the face chooser arrow keys
+// currently apply to.
+type control_focus int
+
+const (
+	FOCUS_FACES control_focus = iota
+	FOCUS_AXES
+	FOCUS_FEATURES
+)
+
+// face_chooser tracks the faces of the family that was selected in the
+// LISTING_FAMILIES state, which one of them is currently highlighted, and
+// (for variable fonts) the axis/feature controls built on top of it.
+type face_chooser struct {
+	family        string
+	faces         []ListedFont
+	idx           int
+	scroll_offset int
+
+	focus           control_focus
+	axis_idx        int
+	feature_idx     int
+	axis_values     map[string]float64
+	enabled_feature map[string]bool
+}
+
+// panels returns the set of control_focus values that currently have
+// something to show, in cycle order, used by Tab to move between them.
+func (f *face_chooser) panels() []control_focus {
+	panels := []control_focus{FOCUS_FACES}
+	if cur, ok := f.Current(); ok {
+		if cur.IsVariable && len(cur.Axes) > 0 {
+			panels = append(panels, FOCUS_AXES)
+		}
+		if len(cur.Features) > 0 {
+			panels = append(panels, FOCUS_FEATURES)
+		}
+	}
+	return panels
+}
+
+func (f *face_chooser) cycle_focus() {
+	panels := f.panels()
+	for i, p := range panels {
+		if p == f.focus {
+			f.focus = panels[(i+1)%len(panels)]
+			return
+		}
+	}
+	f.focus = FOCUS_FACES
+}
+
+// reset_controls (re)initializes axis_values/enabled_feature for the
+// currently highlighted face, defaulting every axis to its fvar default and
+// every feature to disabled.
+func (f *face_chooser) reset_controls() {
+	f.focus = FOCUS_FACES
+	f.axis_idx = 0
+	f.feature_idx = 0
+	f.axis_values = map[string]float64{}
+	f.enabled_feature = map[string]bool{}
+	if cur, ok := f.Current(); ok {
+		for _, a := range cur.Axes {
+			f.axis_values[a.Tag] = a.Default
+		}
+	}
+}
+
+func (f *face_chooser) Len() int { return len(f.faces) }
+
+// VisibleFaces returns the faces that fit in num_rows, scrolling
+// scroll_offset as necessary to keep the highlighted face visible, the
+// same way FamilyList.Lines keeps the highlighted family visible. It
+// reports the index of the first returned face within f.faces.
+func (f *face_chooser) VisibleFaces(num_rows int) (faces []ListedFont, start int) {
+	if len(f.faces) == 0 || num_rows <= 0 {
+		return nil, 0
+	}
+	if f.idx < f.scroll_offset {
+		f.scroll_offset = f.idx
+	} else if f.idx >= f.scroll_offset+num_rows {
+		f.scroll_offset = f.idx - num_rows + 1
+	}
+	if f.scroll_offset < 0 {
+		f.scroll_offset = 0
+	}
+	end := min(len(f.faces), f.scroll_offset+num_rows)
+	return f.faces[f.scroll_offset:end], f.scroll_offset
+}
+
+func (f *face_chooser) Current() (ListedFont, bool) {
+	if f.idx < 0 || f.idx >= len(f.faces) {
+		return ListedFont{}, false
+	}
+	return f.faces[f.idx], true
+}
+
+func (f *face_chooser) Move(delta int) bool {
+	if len(f.faces) == 0 {
+		return false
+	}
+	ni := (f.idx + delta + len(f.faces)) % len(f.faces)
+	if ni == f.idx {
+		return false
+	}
+	f.idx = ni
+	f.reset_controls()
+	return true
+}
+
+// face_variant_label renders the weight/width/slant of a face the way
+// fontconfig/CoreText list them, e.g. "Bold Condensed Italic".
+func face_variant_label(f ListedFont) string {
+	parts := make([]string, 0, 3)
+	switch {
+	case f.Weight >= 700:
+		parts = append(parts, "Bold")
+	case f.Weight >= 600:
+		parts = append(parts, "Semi-Bold")
+	case f.Weight <= 300:
+		parts = append(parts, "Light")
+	}
+	switch {
+	case f.Width <= 75:
+		parts = append(parts, "Condensed")
+	case f.Width >= 125:
+		parts = append(parts, "Expanded")
+	}
+	if f.Slant != 0 {
+		parts = append(parts, "Italic")
+	}
+	if len(parts) == 0 {
+		return "Regular"
+	}
+	return strings.Join(parts, " ")
+}
+
+func (h *handler) enter_choosing_faces() {
+	family := h.family_list.CurrentFamily()
+	if family == "" {
+		return
+	}
+	h.faces = face_chooser{family: family, faces: h.fonts[family]}
+	h.faces.reset_controls()
+	h.state = CHOOSING_FACES
+	h.draw_screen()
+}
+
+func (h *handler) leave_choosing_faces() {
+	h.faces = face_chooser{}
+	h.state = LISTING_FAMILIES
+	h.draw_screen()
+}
+
+func (h *handler) draw_choosing_faces_screen() (err error) {
+	sz, err := h.lp.ScreenSize()
+	if err != nil {
+		return err
+	}
+	h.lp.SetCursorVisible(false)
+	h.lp.PrintStyled("bold", h.faces.family)
+	h.lp.Println()
+	left_width := max(20, int(sz.WidthCells)/3)
+	num_rows := max(0, int(sz.HeightCells)-2)
+	visible, start := h.faces.VisibleFaces(num_rows)
+	for i, f := range visible {
+		label := face_variant_label(f)
+		if start+i == h.faces.idx {
+			h.lp.PrintStyled("fg=green", ">")
+			h.lp.PrintStyled("fg=green bold", label)
+		} else {
+			h.lp.QueueWriteString(" " + label)
+		}
+		h.lp.MoveCursorHorizontally(left_width - len([]rune(label)) - 1)
+		h.lp.Println(SEPARATOR)
+	}
+	if cur, ok := h.faces.Current(); ok {
+		h.render_sample(cur, left_width+2, 2)
+		h.draw_controls(cur, left_width+2, 5)
+	}
+	return
+}
+
+func (h *handler) handle_choosing_faces_key_event(event *loop.KeyEvent) (err error) {
+	if event.MatchesPressOrRepeat("ctrl+c") {
+		h.lp.Quit(1)
+		event.Handled = true
+		return
+	}
+	if event.MatchesPressOrRepeat("esc") || event.MatchesPressOrRepeat("backspace") {
+		h.leave_choosing_faces()
+		event.Handled = true
+		return
+	}
+	if event.MatchesPressOrRepeat("tab") || event.MatchesPressOrRepeat("shift+tab") {
+		h.faces.cycle_focus()
+		h.draw_screen()
+		event.Handled = true
+		return
+	}
+	if event.MatchesPressOrRepeat("down") {
+		changed := false
+		switch h.faces.focus {
+		case FOCUS_FACES:
+			changed = h.faces.Move(1)
+		case FOCUS_AXES:
+			changed = h.faces.move_axis(1)
+		case FOCUS_FEATURES:
+			changed = h.faces.move_feature(1)
+		}
+		if changed {
+			h.draw_screen()
+		}
+		event.Handled = true
+		return
+	}
+	if event.MatchesPressOrRepeat("up") {
+		changed := false
+		switch h.faces.focus {
+		case FOCUS_FACES:
+			changed = h.faces.Move(-1)
+		case FOCUS_AXES:
+			changed = h.faces.move_axis(-1)
+		case FOCUS_FEATURES:
+			changed = h.faces.move_feature(-1)
+		}
+		if changed {
+			h.draw_screen()
+		}
+		event.Handled = true
+		return
+	}
+	if event.MatchesPressOrRepeat("right") && h.faces.focus == FOCUS_AXES {
+		if h.faces.adjust_axis(1, event.Mods&loop.SHIFT != 0) {
+			h.draw_screen()
+		}
+		event.Handled = true
+		return
+	}
+	if event.MatchesPressOrRepeat("left") && h.faces.focus == FOCUS_AXES {
+		if h.faces.adjust_axis(-1, event.Mods&loop.SHIFT != 0) {
+			h.draw_screen()
+		}
+		event.Handled = true
+		return
+	}
+	if (event.MatchesPressOrRepeat("space") || event.MatchesPressOrRepeat("enter")) && h.faces.focus == FOCUS_FEATURES {
+		if h.faces.toggle_current_feature() {
+			h.draw_screen()
+		}
+		event.Handled = true
+		return
+	}
+	if event.MatchesPressOrRepeat("enter") {
+		h.accept_controls()
+		event.Handled = true
+		return
+	}
+	return
+}
+
+func (h *handler) handle_choosing_faces_text(text string, from_key_event bool, in_bracketed_paste bool) (err error) {
+	return
+}
+
+// }}}
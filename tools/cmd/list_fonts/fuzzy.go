@@ -0,0 +1,100 @@
+package list_fonts
+
+import (
+	"sort"
+	"unicode"
+)
+
+// fuzzy_match is a single candidate that matched a fuzzy pattern, together
+// with the score it was ranked by and the indices (into the rune slice of
+// Str) of the runes that matched the pattern.
+type fuzzy_match struct {
+	Str            string
+	Index          int
+	Score          int
+	MatchedIndices []int
+}
+
+const (
+	first_char_bonus     = 10
+	boundary_bonus       = 8
+	consecutive_bonus    = 5
+	gap_penalty_per_rune = 2
+	case_sensitive_bonus = 1
+)
+
+func is_boundary_rune(r rune) bool {
+	return r == ' ' || r == '-' || r == '_' || r == '.'
+}
+
+// fuzzy_score walks pattern left to right against target, matching each
+// pattern rune to its first remaining occurrence in target, and returns the
+// score of that match along with the matched rune indices, or ok=false if
+// pattern is not a subsequence of target. Later occurrences that would
+// score higher are not tried. This mirrors the scoring heuristics of
+// sahilm/fuzzy: matches at the start of the string, matches immediately
+// after a word boundary and runs of consecutive matches are rewarded,
+// while gaps between matches are penalized by their length.
+func fuzzy_score(pattern, target string) (score int, indices []int, ok bool) {
+	if pattern == "" {
+		return 0, nil, true
+	}
+	p := []rune(pattern)
+	t := []rune(target)
+	pl := make([]rune, len(p))
+	for i, r := range p {
+		pl[i] = unicode.ToLower(r)
+	}
+	tl := make([]rune, len(t))
+	for i, r := range t {
+		tl[i] = unicode.ToLower(r)
+	}
+	indices = make([]int, 0, len(p))
+	ti, pi := 0, 0
+	last_match := -1
+	for pi < len(pl) {
+		found := false
+		for ; ti < len(tl); ti++ {
+			if tl[ti] == pl[pi] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, nil, false
+		}
+		if ti == 0 {
+			score += first_char_bonus
+		} else if is_boundary_rune(t[ti-1]) {
+			score += boundary_bonus
+		}
+		if last_match == ti-1 {
+			score += consecutive_bonus
+		} else if last_match >= 0 {
+			score -= (ti - last_match - 1) * gap_penalty_per_rune
+		}
+		if t[ti] == p[pi] {
+			score += case_sensitive_bonus
+		}
+		indices = append(indices, ti)
+		last_match = ti
+		ti++
+		pi++
+	}
+	return score, indices, true
+}
+
+// fuzzy_find_families ranks families against pattern in descending order of
+// score. Families that do not contain pattern as a subsequence are dropped.
+func fuzzy_find_families(pattern string, families []string) []fuzzy_match {
+	matches := make([]fuzzy_match, 0, len(families))
+	for i, f := range families {
+		score, indices, ok := fuzzy_score(pattern, f)
+		if !ok {
+			continue
+		}
+		matches = append(matches, fuzzy_match{Str: f, Index: i, Score: score, MatchedIndices: indices})
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Score > matches[j].Score })
+	return matches
+}
@@ -0,0 +1,80 @@
+package list_fonts
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFuzzyScoreEmptyPattern(t *testing.T) {
+	score, indices, ok := fuzzy_score("", "Fira Code")
+	if !ok || score != 0 || indices != nil {
+		t.Fatalf("empty pattern should trivially match everything, got score=%d indices=%v ok=%v", score, indices, ok)
+	}
+}
+
+func TestFuzzyScoreNoMatch(t *testing.T) {
+	if _, _, ok := fuzzy_score("xyz", "Fira Code"); ok {
+		t.Fatalf("pattern that is not a subsequence should not match")
+	}
+}
+
+func TestFuzzyScoreConsecutiveBeatsGapped(t *testing.T) {
+	// "fir" is consecutive in "Fira Code" but gapped in "Fedora Iris".
+	consecutive, _, ok := fuzzy_score("fir", "Fira Code")
+	if !ok {
+		t.Fatalf("expected match")
+	}
+	gapped, _, ok := fuzzy_score("fir", "Fedora Iris")
+	if !ok {
+		t.Fatalf("expected match")
+	}
+	if consecutive <= gapped {
+		t.Fatalf("consecutive match (%d) should score higher than a gapped one (%d)", consecutive, gapped)
+	}
+}
+
+func TestFuzzyScoreBoundaryBonus(t *testing.T) {
+	// "c" matches the leading rune of "Code" right after a space (a
+	// boundary) in "Fira Code", while in "xcx" it matches mid-word with no
+	// boundary before it.
+	boundary, _, ok := fuzzy_score("c", "Fira Code")
+	if !ok {
+		t.Fatalf("expected match")
+	}
+	mid_word, _, ok := fuzzy_score("c", "xcx")
+	if !ok {
+		t.Fatalf("expected match")
+	}
+	if boundary <= mid_word {
+		t.Fatalf("match right after a boundary (%d) should score higher than a mid-word match (%d)", boundary, mid_word)
+	}
+}
+
+func TestFuzzyScoreMatchedIndices(t *testing.T) {
+	_, indices, ok := fuzzy_score("fc", "Fira Code")
+	if !ok {
+		t.Fatalf("expected match")
+	}
+	if !reflect.DeepEqual(indices, []int{0, 5}) {
+		t.Fatalf("expected indices [0 5], got %v", indices)
+	}
+}
+
+func TestFuzzyFindFamiliesRanksByScore(t *testing.T) {
+	families := []string{"Fedora Iris", "Fira Code", "Zapf Dingbats"}
+	matches := fuzzy_find_families("fir", families)
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 matches, got %d: %v", len(matches), matches)
+	}
+	if matches[0].Str != "Fira Code" {
+		t.Fatalf("expected the consecutive match to rank first, got %q", matches[0].Str)
+	}
+}
+
+func TestFuzzyFindFamiliesEmptyPatternMatchesAll(t *testing.T) {
+	families := []string{"Fira Code", "Zapf Dingbats"}
+	matches := fuzzy_find_families("", families)
+	if len(matches) != len(families) {
+		t.Fatalf("expected all %d families to match an empty pattern, got %d", len(families), len(matches))
+	}
+}
@@ -0,0 +1,224 @@
+package list_fonts
+
+import "reflect"
+
+// ListedFont describes a single font face as reported by the OS font
+// backend (fontconfig on Linux, Core Text on macOS).
+type ListedFont struct {
+	Family      string
+	Full        string
+	PSName      string
+	Path        string
+	Index       int
+	IsMonospace bool
+	IsVariable  bool
+	// Weight, Width and Slant follow the OpenType os2 conventions
+	// (400 = regular weight, 100 = normal width, 0 = upright slant).
+	Weight float64
+	Width  float64
+	Slant  float64
+
+	// Scripts are the Unicode blocks/scripts the face's cmap covers, e.g.
+	// "Latin", "Greek and Coptic", "Cyrillic".
+	Scripts []string
+	// Features are the OpenType GSUB/GPOS feature tags the face exposes,
+	// e.g. "liga", "calt", "ss01", "cv01".
+	Features []string
+	// Axes are the variable-font axes the face exposes, empty unless
+	// IsVariable is true.
+	Axes []FontAxis
+}
+
+// FontAxis describes a single axis of a variable font, following the
+// fvar table's registered and custom axis tags (wght, wdth, slnt, opsz, ...).
+type FontAxis struct {
+	Tag     string
+	Name    string
+	Min     float64
+	Default float64
+	Max     float64
+}
+
+// Line is a single renderable row of the family list. Keeping match_indices
+// here rather than recomputing them at draw time keeps matching logic in
+// FamilyList and presentation in draw_listing_screen.
+type Line struct {
+	text          string
+	width         int
+	is_current    bool
+	match_indices []int
+}
+
+// FamilyList holds the full set of known family names together with the
+// subset currently matching the search text and the user's position in it.
+type FamilyList struct {
+	families        []string
+	current         []string
+	current_indices [][]int
+	idx             int
+	scroll_offset   int
+	max_width       int
+}
+
+func (f *FamilyList) UpdateFamilies(families []string) {
+	f.families = families
+	f.current = families
+	f.current_indices = nil
+	f.idx = 0
+	f.scroll_offset = 0
+	f.update_max_width()
+}
+
+func (f *FamilyList) update_max_width() {
+	f.max_width = 0
+	for _, q := range f.current {
+		if w := len([]rune(q)); w > f.max_width {
+			f.max_width = w
+		}
+	}
+}
+
+func (f *FamilyList) Len() int { return len(f.current) }
+
+func (f *FamilyList) CurrentFamily() string {
+	if f.idx < 0 || f.idx >= len(f.current) {
+		return ""
+	}
+	return f.current[f.idx]
+}
+
+// UpdateSearch ranks families by fuzzy_score against text and reports
+// whether the matches or their matched rune indices changed, so callers can
+// avoid a full redraw when the search text changed but neither did. An empty
+// text falls back to the unranked, unfiltered family list.
+func (f *FamilyList) UpdateSearch(text string) bool {
+	var matches []string
+	var indices [][]int
+	if text == "" {
+		matches = f.families
+	} else {
+		for _, m := range fuzzy_find_families(text, f.families) {
+			matches = append(matches, m.Str)
+			indices = append(indices, m.MatchedIndices)
+		}
+	}
+	changed := !string_slices_equal(f.current, matches) || !reflect.DeepEqual(f.current_indices, indices)
+	f.current = matches
+	f.current_indices = indices
+	if f.idx >= len(f.current) {
+		f.idx = max(0, len(f.current)-1)
+	}
+	f.update_max_width()
+	return changed
+}
+
+func string_slices_equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Move shifts the highlighted family by delta, clamping to the ends of the
+// current match list, and reports whether the highlight actually moved.
+func (f *FamilyList) Move(delta int) bool {
+	if len(f.current) == 0 {
+		return false
+	}
+	ni := f.idx + delta
+	if ni < 0 {
+		ni = 0
+	}
+	if ni >= len(f.current) {
+		ni = len(f.current) - 1
+	}
+	if ni == f.idx {
+		return false
+	}
+	f.idx = ni
+	return true
+}
+
+// ScrollBy shifts the viewport by delta rows, clamped to the ends of the
+// current match list, without moving the highlighted family unless it
+// would fall outside the new viewport - e.g. scrolling the mouse wheel
+// should move what's visible, not silently re-select a different family.
+// Reports whether anything (viewport or highlight) changed.
+func (f *FamilyList) ScrollBy(delta int, num_rows int) bool {
+	if len(f.current) == 0 || num_rows <= 0 {
+		return false
+	}
+	max_offset := max(0, len(f.current)-num_rows)
+	no := f.scroll_offset + delta
+	if no < 0 {
+		no = 0
+	}
+	if no > max_offset {
+		no = max_offset
+	}
+	changed := no != f.scroll_offset
+	f.scroll_offset = no
+	if f.idx < f.scroll_offset {
+		f.idx = f.scroll_offset
+		changed = true
+	} else if f.idx >= f.scroll_offset+num_rows {
+		f.idx = f.scroll_offset + num_rows - 1
+		changed = true
+	}
+	return changed
+}
+
+// IndexForRow maps a row of the currently visible listing (0-based, as
+// drawn by draw_listing_screen) to an index into the current match list,
+// using the same scroll_offset the last call to Lines left behind.
+func (f *FamilyList) IndexForRow(row int) (int, bool) {
+	if row < 0 {
+		return 0, false
+	}
+	idx := f.scroll_offset + row
+	if idx >= len(f.current) {
+		return 0, false
+	}
+	return idx, true
+}
+
+// SetIndex highlights the family at index i, reporting whether it changed.
+func (f *FamilyList) SetIndex(i int) bool {
+	if i < 0 || i >= len(f.current) || i == f.idx {
+		return false
+	}
+	f.idx = i
+	return true
+}
+
+// Lines returns the rows that fit in num_rows, scrolling as necessary to
+// keep the highlighted family visible.
+func (f *FamilyList) Lines(num_rows int) []Line {
+	if len(f.current) == 0 || num_rows <= 0 {
+		return nil
+	}
+	if f.idx < f.scroll_offset {
+		f.scroll_offset = f.idx
+	} else if f.idx >= f.scroll_offset+num_rows {
+		f.scroll_offset = f.idx - num_rows + 1
+	}
+	if f.scroll_offset < 0 {
+		f.scroll_offset = 0
+	}
+	end := min(len(f.current), f.scroll_offset+num_rows)
+	lines := make([]Line, 0, end-f.scroll_offset)
+	for i := f.scroll_offset; i < end; i++ {
+		fam := f.current[i]
+		l := Line{text: fam, width: len([]rune(fam)), is_current: i == f.idx}
+		if i < len(f.current_indices) {
+			l.match_indices = f.current_indices[i]
+		}
+		lines = append(lines, l)
+	}
+	return lines
+}
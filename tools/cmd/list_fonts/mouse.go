@@ -0,0 +1,61 @@
+package list_fonts
+
+import "kitty/tools/tui/loop"
+
+// on_mouse_event is the loop.Loop mouse hook. Mouse support degrades
+// gracefully: if the terminal never turned on mouse reporting there is
+// nothing to dispatch here, events simply won't arrive.
+func (h *handler) on_mouse_event(event *loop.MouseEvent) (err error) {
+	if !h.lp.MouseTrackingEnabled {
+		return
+	}
+	switch h.state {
+	case LISTING_FAMILIES:
+		return h.handle_listing_mouse_event(event)
+	}
+	return
+}
+
+// handle_listing_mouse_event translates a click's cell y into a family
+// index using the same row math draw_listing_screen uses, and turns the
+// wheel into single-entry viewport scrolling.
+func (h *handler) handle_listing_mouse_event(event *loop.MouseEvent) (err error) {
+	sz, err := h.lp.ScreenSize()
+	if err != nil {
+		return err
+	}
+	num_rows := max(0, int(sz.HeightCells)-1)
+	if event.WheelUp() {
+		if h.family_list.ScrollBy(-1, num_rows) {
+			h.draw_screen()
+		}
+		return
+	}
+	if event.WheelDown() {
+		if h.family_list.ScrollBy(1, num_rows) {
+			h.draw_screen()
+		}
+		return
+	}
+	if !event.Buttons.Left || (event.Kind != loop.MOUSE_CLICK && event.Kind != loop.MOUSE_DOUBLE_CLICK) {
+		return
+	}
+	row := event.Cell.Y - 1
+	if row < 0 || row >= num_rows {
+		return
+	}
+	idx, ok := h.family_list.IndexForRow(row)
+	if !ok {
+		return
+	}
+	was_current := idx == h.family_list.idx
+	changed := h.family_list.SetIndex(idx)
+	if event.Kind == loop.MOUSE_DOUBLE_CLICK || (was_current && !changed) {
+		h.enter_choosing_faces()
+		return
+	}
+	if changed {
+		h.draw_screen()
+	}
+	return
+}
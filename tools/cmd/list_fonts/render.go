@@ -0,0 +1,66 @@
+package list_fonts
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// render_sample draws h.sample_text for f at column x, using the existing
+// text-renderer path: the sample is styled with the SGR attributes that
+// approximate the face as currently configured by the axis sliders and
+// feature toggles in h.faces (falling back to f's static weight/slant for
+// non-variable faces), since most terminals, including kitty, select
+// glyphs for a cell from whatever font matches the active SGR state rather
+// than from an arbitrary face path. A caption identifying the exact face
+// and the active feature tags follows on the next row.
+func (h *handler) render_sample(f ListedFont, x, y int) {
+	h.lp.MoveCursorTo(x, y)
+	style := sgr_style_for(f, h.faces.axis_values)
+	if style == "" {
+		h.lp.QueueWriteString(h.sample_text)
+	} else {
+		h.lp.PrintStyled(style, h.sample_text)
+	}
+	h.lp.MoveCursorTo(x, y+1)
+	caption := fmt.Sprintf("%s (%s)", f.PSName, f.Path)
+	if tags := active_feature_tags(h.faces.enabled_feature); len(tags) > 0 {
+		caption += " " + strings.Join(tags, " ")
+	}
+	h.lp.QueueWriteString(caption)
+}
+
+// sgr_style_for returns the loop.Loop style spec (as accepted by
+// PrintStyled) that best approximates f's weight and slant, preferring the
+// live wght/slnt axis values (if the face is variable and exposes them)
+// over f's static defaults so moving a slider updates the preview.
+func sgr_style_for(f ListedFont, axis_values map[string]float64) string {
+	weight, slant := f.Weight, f.Slant
+	if v, ok := axis_values["wght"]; ok {
+		weight = v
+	}
+	if v, ok := axis_values["slnt"]; ok {
+		slant = v
+	}
+	var parts []string
+	if weight >= 600 {
+		parts = append(parts, "bold")
+	}
+	if slant != 0 {
+		parts = append(parts, "italic")
+	}
+	return strings.Join(parts, " ")
+}
+
+// active_feature_tags returns the enabled OpenType feature tags, sorted,
+// prefixed with "+" the same way feature_spec formats them.
+func active_feature_tags(enabled map[string]bool) []string {
+	tags := make([]string, 0, len(enabled))
+	for tag, on := range enabled {
+		if on {
+			tags = append(tags, "+"+tag)
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
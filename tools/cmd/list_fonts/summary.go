@@ -0,0 +1,73 @@
+package list_fonts
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// summarize_family builds the lines shown in the family summary panel for
+// the given faces, which are all assumed to belong to the same family.
+func summarize_family(faces []ListedFont) []string {
+	lines := make([]string, 0, 8)
+	lines = append(lines, fmt.Sprintf("%d face(s)", len(faces)))
+	spacing := "Proportional"
+	for _, f := range faces {
+		if f.IsMonospace {
+			spacing = "Monospace"
+			break
+		}
+	}
+	lines = append(lines, spacing)
+
+	scripts := union_sorted(faces, func(f ListedFont) []string { return f.Scripts })
+	if len(scripts) > 0 {
+		lines = append(lines, "Scripts: "+strings.Join(scripts, ", "))
+	}
+
+	for _, f := range faces {
+		if !f.IsVariable || len(f.Axes) == 0 {
+			continue
+		}
+		lines = append(lines, "Axes:")
+		for _, a := range f.Axes {
+			lines = append(lines, fmt.Sprintf("  %s %g..%g (default %g)", a.Tag, a.Min, a.Max, a.Default))
+		}
+		break
+	}
+
+	features := union_sorted(faces, func(f ListedFont) []string { return f.Features })
+	if len(features) > 0 {
+		lines = append(lines, "Features: "+strings.Join(features, ", "))
+	}
+	return lines
+}
+
+func union_sorted(faces []ListedFont, get func(ListedFont) []string) []string {
+	seen := make(map[string]bool)
+	for _, f := range faces {
+		for _, s := range get(f) {
+			seen[s] = true
+		}
+	}
+	out := make([]string, 0, len(seen))
+	for s := range seen {
+		out = append(out, s)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// truncate_to_width truncates text to at most width cells, appending an
+// ellipsis when it had to cut anything off, so narrow terminals degrade
+// gracefully instead of wrapping.
+func truncate_to_width(text string, width int) string {
+	runes := []rune(text)
+	if len(runes) <= width {
+		return text
+	}
+	if width <= 1 {
+		return string(runes[:max(0, width)])
+	}
+	return string(runes[:width-1]) + "…"
+}
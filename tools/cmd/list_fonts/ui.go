@@ -21,13 +21,18 @@ const (
 )
 
 type handler struct {
-	lp    *loop.Loop
-	fonts map[string][]ListedFont
-	state State
+	lp            *loop.Loop
+	fonts         map[string][]ListedFont
+	state         State
+	sample_text   string
+	accepted_spec string
 
 	// Listing
 	rl          *readline.Readline
 	family_list FamilyList
+
+	// Choosing faces
+	faces face_chooser
 }
 
 // Listing families {{{
@@ -45,11 +50,70 @@ func (h *handler) draw_search_bar() {
 
 const SEPARATOR = "║"
 
+// draw_family_summary renders a right-hand panel describing the family
+// currently highlighted in the family list: face count, spacing, script
+// coverage, variable-font axes and OpenType feature tags. It is drawn in
+// the column to the right of the family list's SEPARATOR.
 func (h *handler) draw_family_summary() (err error) {
-	// TODO: Implement me
+	family := h.family_list.CurrentFamily()
+	faces := h.fonts[family]
+	if len(faces) == 0 {
+		return
+	}
+	sz, err := h.lp.ScreenSize()
+	if err != nil {
+		return err
+	}
+	// draw_listing_screen puts SEPARATOR in column max_width+3 (one
+	// marker column, the name, then padding), so the panel starts after it.
+	x := h.family_list.max_width + 4
+	avail := int(sz.WidthCells) - x
+	if avail < 8 {
+		return
+	}
+	for i, line := range summarize_family(faces) {
+		h.lp.MoveCursorTo(x, i+1)
+		h.lp.QueueWriteString(truncate_to_width(line, avail))
+	}
 	return
 }
 
+// write_family_line prints l.text under base_style, additionally marking
+// the fuzzy-matched runes (l.match_indices) with underline so a search
+// query's contribution to the ranking is visible, not just the score.
+func (h *handler) write_family_line(l Line, base_style string) {
+	if len(l.match_indices) == 0 {
+		if base_style == "" {
+			h.lp.QueueWriteString(l.text)
+		} else {
+			h.lp.PrintStyled(base_style, l.text)
+		}
+		return
+	}
+	runes := []rune(l.text)
+	matched := make(map[int]bool, len(l.match_indices))
+	for _, idx := range l.match_indices {
+		matched[idx] = true
+	}
+	for i := 0; i < len(runes); {
+		j := i
+		for j < len(runes) && matched[j] == matched[i] {
+			j++
+		}
+		run := string(runes[i:j])
+		style := base_style
+		if matched[i] {
+			style = strings.TrimSpace(style + " underline")
+		}
+		if style == "" {
+			h.lp.QueueWriteString(run)
+		} else {
+			h.lp.PrintStyled(style, run)
+		}
+		i = j
+	}
+}
+
 func (h *handler) draw_listing_screen() (err error) {
 	sz, err := h.lp.ScreenSize()
 	if err != nil {
@@ -57,16 +121,13 @@ func (h *handler) draw_listing_screen() (err error) {
 	}
 	num_rows := max(0, int(sz.HeightCells)-1)
 	mw := h.family_list.max_width + 1
-	green_fg, _, _ := strings.Cut(h.lp.SprintStyled("fg=green", "|"), "|")
 	for _, l := range h.family_list.Lines(num_rows) {
-		line := l.text
 		if l.is_current {
-			line = strings.ReplaceAll(line, MARK_AFTER, green_fg)
 			h.lp.PrintStyled("fg=green", ">")
-			h.lp.PrintStyled("fg=green bold", line)
+			h.write_family_line(l, "fg=green bold")
 		} else {
 			h.lp.PrintStyled("fg=green", " ")
-			h.lp.QueueWriteString(line)
+			h.write_family_line(l, "")
 		}
 		h.lp.MoveCursorHorizontally(mw - l.width)
 		h.lp.Println(SEPARATOR)
@@ -100,8 +161,23 @@ func (h *handler) handle_listing_key_event(event *loop.KeyEvent) (err error) {
 		event.Handled = true
 		return
 	}
+	if event.MatchesPressOrRepeat("down") || event.MatchesPressOrRepeat("ctrl+n") {
+		if h.family_list.Move(1) {
+			h.draw_screen()
+		}
+		event.Handled = true
+		return
+	}
+	if event.MatchesPressOrRepeat("up") || event.MatchesPressOrRepeat("ctrl+p") {
+		if h.family_list.Move(-1) {
+			h.draw_screen()
+		}
+		event.Handled = true
+		return
+	}
 	if err = h.rl.OnKeyEvent(event); err != nil {
 		if err == readline.ErrAcceptInput {
+			h.enter_choosing_faces()
 			return nil
 		}
 		return err
@@ -144,6 +220,8 @@ func (h *handler) draw_screen() (err error) {
 	switch h.state {
 	case LISTING_FAMILIES:
 		return h.draw_listing_screen()
+	case CHOOSING_FACES:
+		return h.draw_choosing_faces_screen()
 	}
 	return
 }
@@ -156,6 +234,8 @@ func (h *handler) on_key_event(event *loop.KeyEvent) (err error) {
 	switch h.state {
 	case LISTING_FAMILIES:
 		return h.handle_listing_key_event(event)
+	case CHOOSING_FACES:
+		return h.handle_choosing_faces_key_event(event)
 	}
 	return
 }
@@ -164,6 +244,8 @@ func (h *handler) on_text(text string, from_key_event bool, in_bracketed_paste b
 	switch h.state {
 	case LISTING_FAMILIES:
 		return h.handle_listing_text(text, from_key_event, in_bracketed_paste)
+	case CHOOSING_FACES:
+		return h.handle_choosing_faces_text(text, from_key_event, in_bracketed_paste)
 	}
 	return
 }